@@ -0,0 +1,372 @@
+// Package client talks to the Google Keep "changes" endpoint and keeps the
+// local view of a user's notes and lists in sync with the server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/natefinch/keep/wire"
+)
+
+// changesURL is the RPC Google Keep's own clients use to list, create, and
+// mutate notes.  A single call both uploads local changes and downloads
+// anything new since the last sync.
+const changesURL = "https://www.googleapis.com/notes/v1/changes"
+
+// clientVersion identifies us to the changes RPC the way a real Keep client
+// would identify its platform and app version.
+var clientVersion = map[string]string{
+	"major":    "9",
+	"minor":    "9",
+	"build":    "9",
+	"revision": "9",
+}
+
+// Client talks to the Google Keep API on behalf of a single account.  A
+// Client is not safe for concurrent use.
+type Client struct {
+	hc      *http.Client
+	store   Store
+	baseURL string
+
+	cursor string
+}
+
+// Option configures a Client constructed with New.
+type Option func(*Client)
+
+// WithStore overrides the default file-backed cursor Store.
+func WithStore(s Store) Option {
+	return func(c *Client) { c.store = s }
+}
+
+// New creates a Client that authenticates requests using ts, refreshing and
+// reusing tokens transparently via oauth2.  The returned Client loads its
+// sync cursor from its Store (a file in the user's cache directory, unless
+// overridden with WithStore) so that the first call to Sync after New only
+// fetches changes made since the last run.
+func New(ctx context.Context, ts oauth2.TokenSource, opts ...Option) (*Client, error) {
+	c := &Client{
+		hc:      oauth2.NewClient(ctx, ts),
+		baseURL: changesURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.store == nil {
+		path, err := defaultCursorPath()
+		if err != nil {
+			return nil, fmt.Errorf("client: finding default cursor store: %w", err)
+		}
+		c.store = NewFileStore(path)
+	}
+
+	cursor, err := c.store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: loading sync cursor: %w", err)
+	}
+	c.cursor = cursor
+
+	return c, nil
+}
+
+// Snapshot is the set of nodes returned by a call to the changes RPC,
+// already sorted into their concrete wire types.
+type Snapshot struct {
+	Notes []wire.Note
+	Lists []wire.List
+	Items []wire.Item
+}
+
+// changesRequest is the body of the changes RPC.
+type changesRequest struct {
+	Nodes           []json.RawMessage `json:"nodes,omitempty"`
+	ClientTimestamp json.RawMessage   `json:"clientTimestamp"`
+	RequestHeader   requestHeader     `json:"requestHeader"`
+	TargetVersion   string            `json:"targetVersion,omitempty"`
+}
+
+// requestHeader is sent with every changes RPC to identify the client.
+type requestHeader struct {
+	ClientSessionID string            `json:"clientSessionId"`
+	ClientPlatform  string            `json:"clientPlatform"`
+	ClientVersion   map[string]string `json:"clientVersion"`
+}
+
+// changesResponse is the body of a changes RPC response.
+type changesResponse struct {
+	ToVersion     string            `json:"toVersion"`
+	Nodes         []json.RawMessage `json:"nodes,omitempty"`
+	Reauth        bool              `json:"forceFullResync,omitempty"`
+	TruncateDelta bool              `json:"truncated,omitempty"`
+}
+
+// kindProbe is used to peek at a node's type before deciding which wire type
+// to unmarshal it into.
+type kindProbe struct {
+	Type string `json:"type"`
+}
+
+// Sync uploads any nodes in changes and downloads everything the server has
+// recorded since the last call to Sync (or since New, for the first call),
+// returning the resulting Snapshot. The sync cursor is persisted to the
+// Client's Store before Sync returns.
+func (c *Client) Sync(ctx context.Context, changes ...json.RawMessage) (Snapshot, error) {
+	// Encoded with an explicit KeepFormat, rather than relying on
+	// wire.Timestamp's default MarshalJSON, so that another part of the
+	// same process setting wire.MarshalFormat for some other backend can't
+	// silently corrupt the timestamps we send Google Keep.
+	now, err := wire.Timestamp(time.Now()).Format(wire.KeepFormat)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("client: formatting client timestamp: %w", err)
+	}
+
+	req := changesRequest{
+		Nodes:           changes,
+		ClientTimestamp: now,
+		TargetVersion:   c.cursor,
+		RequestHeader: requestHeader{
+			ClientPlatform: "keep-go",
+			ClientVersion:  clientVersion,
+		},
+	}
+
+	var resp changesResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return Snapshot{}, err
+	}
+
+	if resp.Reauth {
+		// The server wants us to throw away our cursor and resync from
+		// scratch, so retry once with no TargetVersion.
+		req.TargetVersion = ""
+		if err := c.do(ctx, req, &resp); err != nil {
+			return Snapshot{}, err
+		}
+	}
+
+	snap, err := splitNodes(resp.Nodes)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	c.cursor = resp.ToVersion
+	if err := c.store.Save(ctx, c.cursor); err != nil {
+		return Snapshot{}, fmt.Errorf("client: saving sync cursor: %w", err)
+	}
+
+	return snap, nil
+}
+
+// List returns every note, list, and item the account currently has,
+// fetching a full resync if this is the first call from a fresh Store.
+func (c *Client) List(ctx context.Context) (Snapshot, error) {
+	return c.Sync(ctx)
+}
+
+// Create uploads a new node (a wire.Note, wire.List, or wire.Item) and
+// returns the server's canonical copy of it, with its ID and Timestamps
+// populated.
+func (c *Client) Create(ctx context.Context, node interface{}) (wire.Node, error) {
+	b, err := json.Marshal(node)
+	if err != nil {
+		return wire.Node{}, fmt.Errorf("client: marshaling node: %w", err)
+	}
+	snap, err := c.Sync(ctx, b)
+	if err != nil {
+		return wire.Node{}, err
+	}
+	return lastNode(snap)
+}
+
+// Update uploads changes to an existing node (a wire.Note, wire.List, or
+// wire.Item addressed by its ID) and returns the server's merged copy.
+func (c *Client) Update(ctx context.Context, node interface{}) (wire.Node, error) {
+	return c.Create(ctx, node)
+}
+
+// Delete permanently removes the node with the given ID.
+func (c *Client) Delete(ctx context.Context, id string) error {
+	b, err := json.Marshal(deletePatch{ID: id, Delete: true})
+	if err != nil {
+		return fmt.Errorf("client: marshaling delete patch: %w", err)
+	}
+	_, err = c.Sync(ctx, b)
+	return err
+}
+
+// Archive marks the node with the given ID as archived, or unarchives it
+// when archived is false.
+func (c *Client) Archive(ctx context.Context, id string, archived bool) error {
+	b, err := json.Marshal(archivePatch{ID: id, IsArchived: archived})
+	if err != nil {
+		return fmt.Errorf("client: marshaling archive patch: %w", err)
+	}
+	_, err = c.Sync(ctx, b)
+	return err
+}
+
+// Trash moves the node with the given ID to the trash, or restores it from
+// the trash when trashed is false.
+func (c *Client) Trash(ctx context.Context, id string, trashed bool) error {
+	ts := wire.Timestamp{}
+	if trashed {
+		ts = wire.Timestamp(time.Now())
+	}
+	// Encoded with an explicit KeepFormat for the same reason as
+	// ClientTimestamp in Sync: this timestamp must always hit the wire the
+	// way Google Keep expects, regardless of wire.MarshalFormat.
+	tb, err := ts.Format(wire.KeepFormat)
+	if err != nil {
+		return fmt.Errorf("client: formatting trashed timestamp: %w", err)
+	}
+
+	b, err := json.Marshal(trashPatch{
+		ID:         id,
+		Timestamps: trashTimestamps{Trashed: tb},
+	})
+	if err != nil {
+		return fmt.Errorf("client: marshaling trash patch: %w", err)
+	}
+	_, err = c.Sync(ctx, b)
+	return err
+}
+
+// SetReminder attaches r to the node with the given ID. It returns an error
+// without contacting the server if r.Period or r.Weekday is set to a value
+// outside wire's known enum.
+func (c *Client) SetReminder(ctx context.Context, id string, r wire.Reminder) error {
+	b, err := json.Marshal(reminderPatch{ID: id, Reminder: r})
+	if err != nil {
+		return fmt.Errorf("client: marshaling reminder patch: %w", err)
+	}
+	_, err = c.Sync(ctx, b)
+	return err
+}
+
+// SetColor changes the color of the node with the given ID. It returns an
+// error without contacting the server if color is set to a value outside
+// wire's known enum.
+func (c *Client) SetColor(ctx context.Context, id string, color wire.Color) error {
+	b, err := json.Marshal(colorPatch{ID: id, Color: color})
+	if err != nil {
+		return fmt.Errorf("client: marshaling color patch: %w", err)
+	}
+	_, err = c.Sync(ctx, b)
+	return err
+}
+
+type deletePatch struct {
+	ID     string `json:"id"`
+	Delete bool   `json:"delete"`
+}
+
+type archivePatch struct {
+	ID         string `json:"id"`
+	IsArchived bool   `json:"isArchived"`
+}
+
+type trashTimestamps struct {
+	Trashed json.RawMessage `json:"trashed"`
+}
+
+type trashPatch struct {
+	ID         string          `json:"id"`
+	Timestamps trashTimestamps `json:"timestamps"`
+}
+
+type reminderPatch struct {
+	ID       string        `json:"id"`
+	Reminder wire.Reminder `json:"reminder"`
+}
+
+type colorPatch struct {
+	ID    string     `json:"id"`
+	Color wire.Color `json:"color"`
+}
+
+// do sends req to the changes RPC and decodes the result into resp,
+// transparently refreshing the OAuth2 token via c.hc when it has expired.
+func (c *Client) do(ctx context.Context, req changesRequest, resp *changesResponse) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("client: marshaling request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("client: building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.hc.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("client: calling changes: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("client: changes returned status %s", httpResp.Status)
+	}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return fmt.Errorf("client: decoding response: %w", err)
+	}
+	return nil
+}
+
+// splitNodes sorts raw nodes from a changes response into a Snapshot by
+// peeking at each one's type field.
+func splitNodes(raw []json.RawMessage) (Snapshot, error) {
+	var snap Snapshot
+	for _, r := range raw {
+		var probe kindProbe
+		if err := json.Unmarshal(r, &probe); err != nil {
+			return Snapshot{}, fmt.Errorf("client: inspecting node type: %w", err)
+		}
+		switch probe.Type {
+		case "NOTE":
+			var n wire.Note
+			if err := json.Unmarshal(r, &n); err != nil {
+				return Snapshot{}, fmt.Errorf("client: decoding note: %w", err)
+			}
+			snap.Notes = append(snap.Notes, n)
+		case "LIST":
+			var l wire.List
+			if err := json.Unmarshal(r, &l); err != nil {
+				return Snapshot{}, fmt.Errorf("client: decoding list: %w", err)
+			}
+			snap.Lists = append(snap.Lists, l)
+		case "LIST_ITEM":
+			var i wire.Item
+			if err := json.Unmarshal(r, &i); err != nil {
+				return Snapshot{}, fmt.Errorf("client: decoding item: %w", err)
+			}
+			snap.Items = append(snap.Items, i)
+		default:
+			return Snapshot{}, fmt.Errorf("client: unknown node type %q", probe.Type)
+		}
+	}
+	return snap, nil
+}
+
+// lastNode returns the single node a Create or Update call expects back,
+// preferring the most specific type present in snap.
+func lastNode(snap Snapshot) (wire.Node, error) {
+	switch {
+	case len(snap.Items) > 0:
+		return snap.Items[len(snap.Items)-1].Node, nil
+	case len(snap.Lists) > 0:
+		return snap.Lists[len(snap.Lists)-1].Node, nil
+	case len(snap.Notes) > 0:
+		return snap.Notes[len(snap.Notes)-1].Node, nil
+	}
+	return wire.Node{}, fmt.Errorf("client: server returned no node")
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/natefinch/keep/wire"
+)
+
+// TestSetColorInvalidValue checks that an out-of-range Color is reported as
+// an error rather than panicking the process.
+func TestSetColorInvalidValue(t *testing.T) {
+	c := &Client{}
+	err := c.SetColor(context.Background(), "some-id", 99)
+	if err == nil {
+		t.Fatal("expected an error for an out-of-range Color, got nil")
+	}
+}
+
+// noopStore is a Store that discards the cursor, for tests that don't care
+// about persistence.
+type noopStore struct{}
+
+func (noopStore) Load(context.Context) (string, error) { return "", nil }
+func (noopStore) Save(context.Context, string) error   { return nil }
+
+// recordingStore is a Store backed by in-memory fields, for tests that need
+// to observe what a Client loads and saves.
+type recordingStore struct {
+	loaded  string
+	loadErr error
+	saved   []string
+}
+
+func (s *recordingStore) Load(context.Context) (string, error) { return s.loaded, s.loadErr }
+func (s *recordingStore) Save(_ context.Context, cursor string) error {
+	s.saved = append(s.saved, cursor)
+	return nil
+}
+
+// TestNewLoadsCursorFromStore checks that New seeds the Client's cursor from
+// the given Store, rather than starting every Client from scratch.
+func TestNewLoadsCursorFromStore(t *testing.T) {
+	store := &recordingStore{loaded: "saved-cursor"}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+
+	c, err := New(context.Background(), ts, WithStore(store))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if c.cursor != "saved-cursor" {
+		t.Fatalf("cursor = %q, want %q", c.cursor, "saved-cursor")
+	}
+}
+
+// TestNewPropagatesStoreLoadError checks that New reports a Store's Load
+// error instead of silently starting from an empty cursor.
+func TestNewPropagatesStoreLoadError(t *testing.T) {
+	store := &recordingStore{loadErr: fmt.Errorf("disk on fire")}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+
+	if _, err := New(context.Background(), ts, WithStore(store)); err == nil {
+		t.Fatal("expected an error when the Store fails to load, got nil")
+	}
+}
+
+// TestSyncIgnoresMarshalFormat checks that Sync always encodes its
+// clientTimestamp in wire.KeepFormat, even if something else in the process
+// has changed the package-level wire.MarshalFormat.
+func TestSyncIgnoresMarshalFormat(t *testing.T) {
+	old := wire.MarshalFormat
+	wire.MarshalFormat = wire.UnixFormat
+	defer func() { wire.MarshalFormat = old }()
+
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"toVersion":"1"}`))
+	}))
+	defer srv.Close()
+
+	c := &Client{hc: srv.Client(), store: noopStore{}, baseURL: srv.URL}
+
+	if _, err := c.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	var req changesRequest
+	if err := json.Unmarshal(gotBody, &req); err != nil {
+		t.Fatalf("decoding captured request body: %v", err)
+	}
+	if !strings.HasPrefix(string(req.ClientTimestamp), `"`) {
+		t.Fatalf("clientTimestamp %s was not encoded in KeepFormat despite wire.MarshalFormat", req.ClientTimestamp)
+	}
+}
+
+// TestSyncAdvancesAndPersistsCursor checks that each call to Sync sends the
+// cursor from the previous response as its TargetVersion, and saves the new
+// cursor to the Store before returning.
+func TestSyncAdvancesAndPersistsCursor(t *testing.T) {
+	var gotTargetVersions []string
+	toVersion := "1"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req changesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		gotTargetVersions = append(gotTargetVersions, req.TargetVersion)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"toVersion":%q}`, toVersion)
+	}))
+	defer srv.Close()
+
+	store := &recordingStore{}
+	c := &Client{hc: srv.Client(), store: store, baseURL: srv.URL}
+
+	if _, err := c.Sync(context.Background()); err != nil {
+		t.Fatalf("first Sync: %v", err)
+	}
+	if c.cursor != "1" {
+		t.Fatalf("cursor after first Sync = %q, want %q", c.cursor, "1")
+	}
+
+	toVersion = "2"
+	if _, err := c.Sync(context.Background()); err != nil {
+		t.Fatalf("second Sync: %v", err)
+	}
+	if c.cursor != "2" {
+		t.Fatalf("cursor after second Sync = %q, want %q", c.cursor, "2")
+	}
+
+	wantTargets := []string{"", "1"}
+	if len(gotTargetVersions) != len(wantTargets) {
+		t.Fatalf("got %d requests, want %d", len(gotTargetVersions), len(wantTargets))
+	}
+	for i, want := range wantTargets {
+		if gotTargetVersions[i] != want {
+			t.Errorf("request %d TargetVersion = %q, want %q", i, gotTargetVersions[i], want)
+		}
+	}
+
+	wantSaved := []string{"1", "2"}
+	if len(store.saved) != len(wantSaved) {
+		t.Fatalf("store got %d saves, want %d", len(store.saved), len(wantSaved))
+	}
+	for i, want := range wantSaved {
+		if store.saved[i] != want {
+			t.Errorf("save %d = %q, want %q", i, store.saved[i], want)
+		}
+	}
+}
+
+// TestSyncReauthRetry checks that when the server asks for a full resync,
+// Sync retries once with an empty TargetVersion instead of surfacing the
+// Reauth response to the caller.
+func TestSyncReauthRetry(t *testing.T) {
+	var calls int32
+	var gotTargetVersions []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req changesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		gotTargetVersions = append(gotTargetVersions, req.TargetVersion)
+		w.Header().Set("Content-Type", "application/json")
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Write([]byte(`{"toVersion":"stale","forceFullResync":true}`))
+			return
+		}
+		w.Write([]byte(`{"toVersion":"fresh"}`))
+	}))
+	defer srv.Close()
+
+	store := &recordingStore{loaded: "stale-cursor"}
+	c := &Client{hc: srv.Client(), store: store, baseURL: srv.URL, cursor: "stale-cursor"}
+
+	snap, err := c.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(snap.Notes) != 0 || len(snap.Lists) != 0 || len(snap.Items) != 0 {
+		t.Fatalf("expected an empty Snapshot, got %+v", snap)
+	}
+	if c.cursor != "fresh" {
+		t.Fatalf("cursor after reauth retry = %q, want %q", c.cursor, "fresh")
+	}
+
+	wantTargets := []string{"stale-cursor", ""}
+	if len(gotTargetVersions) != len(wantTargets) {
+		t.Fatalf("got %d requests, want %d", len(gotTargetVersions), len(wantTargets))
+	}
+	for i, want := range wantTargets {
+		if gotTargetVersions[i] != want {
+			t.Errorf("request %d TargetVersion = %q, want %q", i, gotTargetVersions[i], want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Store persists the sync cursor (the server's toVersion) between runs of
+// the Client, so a restarted process can resume from where it left off
+// instead of fetching every note from scratch.
+type Store interface {
+	// Load returns the last saved cursor, or "" if none has been saved yet.
+	Load(ctx context.Context) (string, error)
+	// Save persists cursor, overwriting any previously saved value.
+	Save(ctx context.Context, cursor string) error
+}
+
+// FileStore is the default Store, backed by a single file on disk holding
+// the cursor as plain text.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore returns a FileStore that reads and writes the cursor at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load implements Store.
+func (f *FileStore) Load(ctx context.Context) (string, error) {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("client: reading cursor file %s: %w", f.path, err)
+	}
+	return string(b), nil
+}
+
+// Save implements Store.
+func (f *FileStore) Save(ctx context.Context, cursor string) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("client: creating cursor directory: %w", err)
+	}
+	if err := os.WriteFile(f.path, []byte(cursor), 0o600); err != nil {
+		return fmt.Errorf("client: writing cursor file %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// defaultCursorPath returns the path New uses for its FileStore when the
+// caller doesn't supply one with WithStore.
+func defaultCursorPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "keep", "cursor"), nil
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileStoreRoundTrip checks that a cursor saved with FileStore.Save comes
+// back unchanged from FileStore.Load.
+func TestFileStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keep", "cursor")
+	f := NewFileStore(path)
+
+	got, err := f.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load on a nonexistent file: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("Load on a nonexistent file = %q, want empty string", got)
+	}
+
+	const want = "some-cursor-value"
+	if err := f.Save(context.Background(), want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err = f.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got != want {
+		t.Fatalf("Load after Save = %q, want %q", got, want)
+	}
+}
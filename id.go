@@ -0,0 +1,18 @@
+package keep
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// newID returns a new random, client-generated node ID in the same
+// (RFC 4122 version 4 UUID) shape Google Keep's own clients use for notes,
+// lists, and items created offline and synced up later.
+func newID() string {
+	var b [16]byte
+	// rand.Read on crypto/rand never returns an error.
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,297 @@
+// Package keep provides a friendly API for building and reading Google Keep
+// notes and lists, on top of the lower-level wire types that mirror Keep's
+// sync protocol.
+package keep
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/natefinch/keep/wire"
+)
+
+// Note is a single free-form text note.
+type Note struct {
+	id    string
+	title string
+	body  string
+
+	archived bool
+	color    wire.Color
+
+	timestamps     wire.Timestamps
+	bodyID         string
+	bodyTimestamps wire.Timestamps
+}
+
+// NewNote returns a new Note with the given title and body text.
+func NewNote(title, body string) *Note {
+	now := wire.Timestamp(time.Now())
+	return &Note{
+		id:             newID(),
+		title:          title,
+		body:           body,
+		bodyID:         newID(),
+		timestamps:     wire.Timestamps{Created: now, Updated: now},
+		bodyTimestamps: wire.Timestamps{Created: now, Updated: now},
+	}
+}
+
+// Title returns the note's title.
+func (n *Note) Title() string { return n.title }
+
+// Body returns the note's body text.
+func (n *Note) Body() string { return n.body }
+
+// SetBody replaces the note's body text.
+func (n *Note) SetBody(body string) {
+	n.body = body
+	n.bodyTimestamps.Updated = wire.Timestamp(time.Now())
+}
+
+// Archived reports whether the note is archived.
+func (n *Note) Archived() bool { return n.archived }
+
+// Archive archives or unarchives the note.
+func (n *Note) Archive(archived bool) { n.archived = archived }
+
+// Color returns the note's color.
+func (n *Note) Color() wire.Color { return n.color }
+
+// SetColor changes the note's color.
+func (n *Note) SetColor(c wire.Color) { n.color = c }
+
+// MarshalJSON implements json.Marshaler, encoding the Note as the pair of
+// wire nodes (the note itself and the child item holding its body text)
+// that Google Keep expects.
+func (n *Note) MarshalJSON() ([]byte, error) {
+	parent := wire.Note{
+		ParentNode: wire.ParentNode{
+			Node: wire.Node{
+				ID:         n.id,
+				Timestamps: n.timestamps,
+			},
+			Title:    n.title,
+			Archived: n.archived,
+			Color:    n.color,
+		},
+	}
+	body := wire.Item{
+		Node: wire.Node{
+			ID:         n.bodyID,
+			ParentID:   n.id,
+			Timestamps: n.bodyTimestamps,
+		},
+		Text: n.body,
+	}
+	return json.Marshal([2]interface{}{parent, body})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the pair of wire nodes
+// MarshalJSON produces back into a Note.
+func (n *Note) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("keep: decoding note: %w", err)
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("keep: expected 2 nodes for a note, got %d", len(raw))
+	}
+
+	var parent wire.Note
+	if err := json.Unmarshal(raw[0], &parent); err != nil {
+		return fmt.Errorf("keep: decoding note node: %w", err)
+	}
+	var body wire.Item
+	if err := json.Unmarshal(raw[1], &body); err != nil {
+		return fmt.Errorf("keep: decoding note body: %w", err)
+	}
+
+	n.id = parent.ID
+	n.title = parent.Title
+	n.archived = parent.Archived
+	n.color = parent.Color
+	n.timestamps = parent.Timestamps
+	n.bodyID = body.ID
+	n.body = body.Text
+	n.bodyTimestamps = body.Timestamps
+	return nil
+}
+
+// ListItem is a single checkable entry in a List.
+type ListItem struct {
+	Text    string
+	Checked bool
+}
+
+// List is a list of checkable items.
+type List struct {
+	id    string
+	title string
+
+	archived bool
+	color    wire.Color
+
+	timestamps wire.Timestamps
+	items      []*listItem
+}
+
+// listItem is the internal, mutable form of a List's entries.
+type listItem struct {
+	id         string
+	text       string
+	checked    bool
+	timestamps wire.Timestamps
+}
+
+// NewList returns a new List with the given title, containing an item for
+// each of items, in order.
+func NewList(title string, items ...string) *List {
+	now := wire.Timestamp(time.Now())
+	l := &List{
+		id:         newID(),
+		title:      title,
+		timestamps: wire.Timestamps{Created: now, Updated: now},
+	}
+	for _, text := range items {
+		l.AddItem(text)
+	}
+	return l
+}
+
+// Title returns the list's title.
+func (l *List) Title() string { return l.title }
+
+// Archived reports whether the list is archived.
+func (l *List) Archived() bool { return l.archived }
+
+// Archive archives or unarchives the list.
+func (l *List) Archive(archived bool) { l.archived = archived }
+
+// Color returns the list's color.
+func (l *List) Color() wire.Color { return l.color }
+
+// SetColor changes the list's color.
+func (l *List) SetColor(c wire.Color) { l.color = c }
+
+// AddItem appends a new, unchecked item with the given text to the list.
+func (l *List) AddItem(text string) {
+	now := wire.Timestamp(time.Now())
+	l.items = append(l.items, &listItem{
+		id:         newID(),
+		text:       text,
+		timestamps: wire.Timestamps{Created: now, Updated: now},
+	})
+}
+
+// Check marks the item at index as checked. It returns an error if index is
+// out of range.
+func (l *List) Check(index int) error {
+	if index < 0 || index >= len(l.items) {
+		return fmt.Errorf("keep: item index %d out of range", index)
+	}
+	l.items[index].checked = true
+	l.items[index].timestamps.Updated = wire.Timestamp(time.Now())
+	return nil
+}
+
+// Uncheck marks the item at index as unchecked. It returns an error if index
+// is out of range.
+func (l *List) Uncheck(index int) error {
+	if index < 0 || index >= len(l.items) {
+		return fmt.Errorf("keep: item index %d out of range", index)
+	}
+	l.items[index].checked = false
+	l.items[index].timestamps.Updated = wire.Timestamp(time.Now())
+	return nil
+}
+
+// Items returns the list's current items, in order.
+func (l *List) Items() []ListItem {
+	out := make([]ListItem, len(l.items))
+	for i, it := range l.items {
+		out[i] = ListItem{Text: it.text, Checked: it.checked}
+	}
+	return out
+}
+
+// MarshalJSON implements json.Marshaler, encoding the List as the wire node
+// for the list itself followed by one wire node per item, the way Google
+// Keep expects.
+func (l *List) MarshalJSON() ([]byte, error) {
+	nodes := make([]interface{}, 0, len(l.items)+1)
+	nodes = append(nodes, wire.List{
+		ParentNode: wire.ParentNode{
+			Node: wire.Node{
+				ID:         l.id,
+				Timestamps: l.timestamps,
+			},
+			Title:    l.title,
+			Archived: l.archived,
+			Color:    l.color,
+		},
+	})
+	for i, it := range l.items {
+		nodes = append(nodes, wire.Item{
+			Node: wire.Node{
+				ID:         it.id,
+				ParentID:   l.id,
+				SortValue:  len(l.items) - i,
+				Timestamps: it.timestamps,
+			},
+			Text:    it.text,
+			Checked: it.checked,
+		})
+	}
+	return json.Marshal(nodes)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the nodes MarshalJSON
+// produces back into a List.
+func (l *List) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("keep: decoding list: %w", err)
+	}
+	if len(raw) == 0 {
+		return fmt.Errorf("keep: expected at least 1 node for a list, got 0")
+	}
+
+	var parent wire.List
+	if err := json.Unmarshal(raw[0], &parent); err != nil {
+		return fmt.Errorf("keep: decoding list node: %w", err)
+	}
+	l.id = parent.ID
+	l.title = parent.Title
+	l.archived = parent.Archived
+	l.color = parent.Color
+	l.timestamps = parent.Timestamps
+
+	items := make([]wire.Item, 0, len(raw)-1)
+	for _, r := range raw[1:] {
+		var item wire.Item
+		if err := json.Unmarshal(r, &item); err != nil {
+			return fmt.Errorf("keep: decoding list item: %w", err)
+		}
+		items = append(items, item)
+	}
+	// MarshalJSON encodes item order as a descending SortValue, independent
+	// of the nodes' position in the array, so decode needs to sort back by
+	// it rather than trust array order.
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SortValue > items[j].SortValue
+	})
+
+	l.items = l.items[:0]
+	for _, item := range items {
+		l.items = append(l.items, &listItem{
+			id:         item.ID,
+			text:       item.Text,
+			checked:    item.Checked,
+			timestamps: item.Timestamps,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package keep
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// These payloads are shaped like the node pairs/groups captured from real
+// Google Keep "changes" sync responses: a parent node (NOTE or LIST)
+// followed by its child node(s) (the body LIST_ITEM for a note, or one
+// LIST_ITEM per entry for a list).
+const (
+	capturedNoteJSON = `[
+		{
+			"id": "168fcb3d92b.491d2cd4516ba7c3",
+			"kind": "notes#node",
+			"parentId": "",
+			"sortValue": 0,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:22:01.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:25:47.000Z",
+				"userEdited": "2021-03-04T18:25:47.000Z"
+			},
+			"title": "Grocery run",
+			"isArchived": false,
+			"color": "GREEN",
+			"type": "NOTE"
+		},
+		{
+			"id": "168fcb3d92c.7e2a441e3ef9b1aa",
+			"kind": "notes#node",
+			"parentId": "168fcb3d92b.491d2cd4516ba7c3",
+			"sortValue": 0,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:22:01.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:25:47.000Z",
+				"userEdited": "2021-03-04T18:25:47.000Z"
+			},
+			"type": "LIST_ITEM",
+			"checked": false,
+			"text": "Pick up milk and eggs"
+		}
+	]`
+
+	capturedListJSON = `[
+		{
+			"id": "168fcb5a001.d9b9f6a1a3e4c2bb",
+			"kind": "notes#node",
+			"parentId": "",
+			"sortValue": 0,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:30:12.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:31:00.000Z",
+				"userEdited": "2021-03-04T18:31:00.000Z"
+			},
+			"title": "Chores",
+			"isArchived": false,
+			"color": "DEFAULT",
+			"type": "LIST"
+		},
+		{
+			"id": "168fcb5a002.2b1e4f9a9c7d4e55",
+			"kind": "notes#node",
+			"parentId": "168fcb5a001.d9b9f6a1a3e4c2bb",
+			"sortValue": 2,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:30:12.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:30:12.000Z",
+				"userEdited": "1970-01-01T00:00:00.000Z"
+			},
+			"type": "LIST_ITEM",
+			"checked": true,
+			"text": "Mow the lawn"
+		},
+		{
+			"id": "168fcb5a003.47e9a8b1f9c3d266",
+			"kind": "notes#node",
+			"parentId": "168fcb5a001.d9b9f6a1a3e4c2bb",
+			"sortValue": 1,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:30:30.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:30:30.000Z",
+				"userEdited": "1970-01-01T00:00:00.000Z"
+			},
+			"type": "LIST_ITEM",
+			"checked": false,
+			"text": "Wash the car"
+		}
+	]`
+
+	// reorderedListJSON is shaped like capturedListJSON, but with its two
+	// item nodes in the opposite order from their sortValue, the way a
+	// real sync response isn't guaranteed not to be.
+	reorderedListJSON = `[
+		{
+			"id": "168fcb5a001.d9b9f6a1a3e4c2bb",
+			"kind": "notes#node",
+			"parentId": "",
+			"sortValue": 0,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:30:12.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:31:00.000Z",
+				"userEdited": "2021-03-04T18:31:00.000Z"
+			},
+			"title": "Chores",
+			"isArchived": false,
+			"color": "DEFAULT",
+			"type": "LIST"
+		},
+		{
+			"id": "168fcb5a003.47e9a8b1f9c3d266",
+			"kind": "notes#node",
+			"parentId": "168fcb5a001.d9b9f6a1a3e4c2bb",
+			"sortValue": 1,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:30:30.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:30:30.000Z",
+				"userEdited": "1970-01-01T00:00:00.000Z"
+			},
+			"type": "LIST_ITEM",
+			"checked": false,
+			"text": "Wash the car"
+		},
+		{
+			"id": "168fcb5a002.2b1e4f9a9c7d4e55",
+			"kind": "notes#node",
+			"parentId": "168fcb5a001.d9b9f6a1a3e4c2bb",
+			"sortValue": 2,
+			"timestamps": {
+				"kind": "notes#timestamps",
+				"created": "2021-03-04T18:30:12.000Z",
+				"deleted": "1970-01-01T00:00:00.000Z",
+				"trashed": "1970-01-01T00:00:00.000Z",
+				"updated": "2021-03-04T18:30:12.000Z",
+				"userEdited": "1970-01-01T00:00:00.000Z"
+			},
+			"type": "LIST_ITEM",
+			"checked": true,
+			"text": "Mow the lawn"
+		}
+	]`
+)
+
+func TestNoteUnmarshal(t *testing.T) {
+	var n Note
+	if err := json.Unmarshal([]byte(capturedNoteJSON), &n); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := n.Title(), "Grocery run"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if got, want := n.Body(), "Pick up milk and eggs"; got != want {
+		t.Errorf("Body() = %q, want %q", got, want)
+	}
+}
+
+func TestListUnmarshal(t *testing.T) {
+	var l List
+	if err := json.Unmarshal([]byte(capturedListJSON), &l); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := l.Title(), "Chores"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	want := []ListItem{
+		{Text: "Mow the lawn", Checked: true},
+		{Text: "Wash the car", Checked: false},
+	}
+	got := l.Items()
+	if len(got) != len(want) {
+		t.Fatalf("Items() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Items()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestListUnmarshalReordersBySortValue checks that a List's items come back
+// in SortValue order, not the order their nodes happened to appear in the
+// JSON array.
+func TestListUnmarshalReordersBySortValue(t *testing.T) {
+	var l List
+	if err := json.Unmarshal([]byte(reorderedListJSON), &l); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []ListItem{
+		{Text: "Mow the lawn", Checked: true},
+		{Text: "Wash the car", Checked: false},
+	}
+	got := l.Items()
+	if len(got) != len(want) {
+		t.Fatalf("Items() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Items()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// FuzzNoteRoundTrip checks that any Note decoded from a captured payload can
+// be re-marshaled and decoded again without losing its title or body.
+func FuzzNoteRoundTrip(f *testing.F) {
+	f.Add([]byte(capturedNoteJSON))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var n Note
+		if err := json.Unmarshal(data, &n); err != nil {
+			t.Skip()
+		}
+
+		b, err := json.Marshal(&n)
+		if err != nil {
+			t.Fatalf("marshaling a successfully decoded Note: %v", err)
+		}
+
+		var n2 Note
+		if err := json.Unmarshal(b, &n2); err != nil {
+			t.Fatalf("unmarshaling our own output: %v", err)
+		}
+
+		if n2.Title() != n.Title() || n2.Body() != n.Body() {
+			t.Fatalf("round trip mismatch: got %+v, want %+v", n2, n)
+		}
+	})
+}
+
+// FuzzListRoundTrip checks that any List decoded from a captured payload can
+// be re-marshaled and decoded again without losing its title or items.
+func FuzzListRoundTrip(f *testing.F) {
+	f.Add([]byte(capturedListJSON))
+	f.Add([]byte(reorderedListJSON))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var l List
+		if err := json.Unmarshal(data, &l); err != nil {
+			t.Skip()
+		}
+
+		b, err := json.Marshal(&l)
+		if err != nil {
+			t.Fatalf("marshaling a successfully decoded List: %v", err)
+		}
+
+		var l2 List
+		if err := json.Unmarshal(b, &l2); err != nil {
+			t.Fatalf("unmarshaling our own output: %v", err)
+		}
+
+		if l2.Title() != l.Title() {
+			t.Fatalf("round trip title mismatch: got %q, want %q", l2.Title(), l.Title())
+		}
+		got, want := l2.Items(), l.Items()
+		if len(got) != len(want) {
+			t.Fatalf("round trip item count mismatch: got %d, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("round trip item %d mismatch: got %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	})
+}
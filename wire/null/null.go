@@ -0,0 +1,128 @@
+// Package null wraps wire's Timestamp, Period, and Color types with a Valid
+// flag, so that callers can tell a field that was explicitly null (or
+// omitted) apart from one that was present with its zero value. Google Keep
+// does both depending on the endpoint and the state of a reminder, and the
+// bare wire types have no way to represent it.
+//
+// The bare wire.Timestamp, wire.Period, and wire.Color types already decode
+// a JSON null into their zero value rather than erroring, so wire.Reminder
+// and friends parse partially-populated sync responses fine without using
+// this package. Reach for null.Timestamp/null.Period/null.Color instead of
+// the bare types only when the zero value is itself a meaningful, distinct
+// value for your use case and you need to know whether the field was
+// actually null on the wire.
+package null
+
+import (
+	"bytes"
+
+	"github.com/natefinch/keep/wire"
+)
+
+var jsonNull = []byte("null")
+
+// Timestamp is a wire.Timestamp that may be null.
+type Timestamp struct {
+	Timestamp wire.Timestamp
+	Valid     bool
+}
+
+// NewTimestamp returns a Timestamp with the given value and validity.
+func NewTimestamp(t wire.Timestamp, valid bool) Timestamp {
+	return Timestamp{Timestamp: t, Valid: valid}
+}
+
+// MarshalJSON implements json.Marshaler. An invalid Timestamp marshals to
+// the JSON null literal.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return jsonNull, nil
+	}
+	return t.Timestamp.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON null literal
+// unmarshals to Valid=false rather than an error.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(jsonNull, b) {
+		*t = Timestamp{}
+		return nil
+	}
+	if err := t.Timestamp.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	t.Valid = true
+	return nil
+}
+
+// Period is a wire.Period that may be null.
+type Period struct {
+	Period wire.Period
+	Valid  bool
+}
+
+// NewPeriod returns a Period with the given value and validity.
+func NewPeriod(p wire.Period, valid bool) Period {
+	return Period{Period: p, Valid: valid}
+}
+
+// MarshalJSON implements json.Marshaler. An invalid Period marshals to the
+// JSON null literal. Unlike wire.Period.MarshalJSON, a valid Period whose
+// value is wire.SpecificTime also marshals to null rather than erroring,
+// since wire.Period has no wire value for SpecificTime. That null is the
+// same encoding an invalid Period gets, so unmarshaling it back yields
+// Valid=false.
+func (p Period) MarshalJSON() ([]byte, error) {
+	if !p.Valid || p.Period == wire.SpecificTime {
+		return jsonNull, nil
+	}
+	return p.Period.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON null literal
+// unmarshals to Valid=false rather than an error.
+func (p *Period) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(jsonNull, b) {
+		*p = Period{}
+		return nil
+	}
+	if err := p.Period.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	p.Valid = true
+	return nil
+}
+
+// Color is a wire.Color that may be null.
+type Color struct {
+	Color wire.Color
+	Valid bool
+}
+
+// NewColor returns a Color with the given value and validity.
+func NewColor(c wire.Color, valid bool) Color {
+	return Color{Color: c, Valid: valid}
+}
+
+// MarshalJSON implements json.Marshaler. An invalid Color marshals to the
+// JSON null literal.
+func (c Color) MarshalJSON() ([]byte, error) {
+	if !c.Valid {
+		return jsonNull, nil
+	}
+	return c.Color.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON null literal
+// unmarshals to Valid=false rather than an error.
+func (c *Color) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(jsonNull, b) {
+		*c = Color{}
+		return nil
+	}
+	if err := c.Color.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	c.Valid = true
+	return nil
+}
@@ -0,0 +1,61 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/natefinch/keep/wire"
+)
+
+func TestTimestampNullRoundTrip(t *testing.T) {
+	var ts Timestamp
+	if err := json.Unmarshal([]byte("null"), &ts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ts.Valid {
+		t.Fatalf("expected Valid=false after unmarshaling null")
+	}
+
+	b, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal([]byte("null"), b) {
+		t.Fatalf("expected: %q, got: %q", "null", b)
+	}
+}
+
+func TestPeriodNullRoundTrip(t *testing.T) {
+	var p Period
+	if err := json.Unmarshal([]byte("null"), &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Valid {
+		t.Fatalf("expected Valid=false after unmarshaling null")
+	}
+
+	valid := NewPeriod(wire.Morning, true)
+	b, err := json.Marshal(valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal([]byte(`"MORNING"`), b) {
+		t.Fatalf("expected: %q, got: %q", `"MORNING"`, b)
+	}
+}
+
+// TestPeriodSpecificTimeMarshal checks that a valid Period holding
+// wire.SpecificTime - the zero value wire.Period.MarshalJSON refuses to
+// encode - still marshals without error, since that's exactly the case
+// null.Period exists for.
+func TestPeriodSpecificTimeMarshal(t *testing.T) {
+	valid := NewPeriod(wire.SpecificTime, true)
+	b, err := json.Marshal(valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal([]byte("null"), b) {
+		t.Fatalf("expected: %q, got: %q", "null", b)
+	}
+}
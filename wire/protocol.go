@@ -3,6 +3,8 @@ package wire
 import (
 	"bytes"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -19,6 +21,13 @@ var (
 	// wire value for the zero time
 	tszero = []byte(`"1970-01-01T00:00:00.000Z"`)
 
+	// jsonNull is the JSON null literal. Google Keep's sync responses
+	// sometimes send this for Period, Color, and Timestamp fields on
+	// partially-populated nodes (e.g. a reminder with no time-of-day set
+	// yet); we treat it the same as the field being absent, i.e. the zero
+	// value.
+	jsonNull = []byte("null")
+
 	// wire values for reminder.state
 	notDismissedVal = []byte(`"INITIAL"`)
 	dismissedVal    = []byte(`"DISMISSED"`)
@@ -38,6 +47,15 @@ var (
 	tealVal    = []byte(`"TEAL"`)
 	blueVal    = []byte(`"BLUE"`)
 	grayVal    = []byte(`"GRAY"`)
+
+	// wire values for recurring reminders' day of the week
+	mondayVal    = []byte(`"MON"`)
+	tuesdayVal   = []byte(`"TUE"`)
+	wednesdayVal = []byte(`"WED"`)
+	thursdayVal  = []byte(`"THU"`)
+	fridayVal    = []byte(`"FRI"`)
+	saturdayVal  = []byte(`"SAT"`)
+	sundayVal    = []byte(`"SUN"`)
 )
 
 const tsLayout = "2006-01-02T15:04:05.000Z"
@@ -91,7 +109,11 @@ type ParentNode struct {
 	Node
 	Title    string `json:"title"`
 	Archived bool   `json:"isArchived"`
-	Color
+	// Color is a named field, rather than embedded anonymously, so that its
+	// MarshalJSON isn't promoted to ParentNode itself: an anonymous Color
+	// field's Marshaler would otherwise take over encoding of the whole
+	// struct, silently dropping every other field.
+	Color Color `json:"color"`
 }
 
 // Node represents an identity of an item of data in google keep.
@@ -123,7 +145,8 @@ type Reminder struct {
 // Time represents when a Reminder should notify the user.  The year, month, and
 // day are always specified.  The time of day is specified either by a generic
 // Period (i.e. Morning, Afternoon, etc), or by a specific time stored in the
-// Hour, Minute, and Second fields.
+// Hour, Minute, and Second fields.  Weekday is only meaningful for recurring
+// reminders, and names the day of the week the reminder repeats on.
 type Time struct {
 	Year  int `json:"year"`
 	Month int `json:"month"`
@@ -134,24 +157,98 @@ type Time struct {
 	Hour   int `json:"hour,omitempty"`
 	Minute int `json:"minute,omitempty"`
 	Second int `json:"second,omitempty"`
+
+	Weekday Weekday `json:"weekday,omitempty"`
+}
+
+// periodHour is the hour of day (24-hour clock) each generic Period
+// corresponds to.
+var periodHour = map[Period]int{
+	Morning:   9,
+	Afternoon: 13,
+	Evening:   17,
+	Night:     20,
+}
+
+// NearestPeriod, passed to NewTime, asks it to pick whichever of Morning,
+// Afternoon, Evening, or Night falls closest to the given time.Time's hour,
+// rather than using an exact Hour/Minute/Second.
+const NearestPeriod Period = -1
+
+// DefaultLocation is the *time.Location Time.Time uses when no WithLocation
+// option is given. It defaults to time.Local; code that knows its users'
+// Keep account timezone should set this once at startup.
+var DefaultLocation = time.Local
+
+// TimeOption configures a single call to Time.Time.
+type TimeOption func(*timeOptions)
+
+type timeOptions struct {
+	loc *time.Location
 }
 
-// Time returns the time.Time value represented by this struct.
-func (t *Time) Time() time.Time {
+// WithLocation sets the *time.Location the time.Time returned by Time.Time
+// is expressed in.
+func WithLocation(loc *time.Location) TimeOption {
+	return func(o *timeOptions) { o.loc = loc }
+}
+
+// Time returns the time.Time value represented by this struct, in
+// DefaultLocation unless overridden with WithLocation.
+func (t *Time) Time(opts ...TimeOption) time.Time {
+	o := timeOptions{loc: DefaultLocation}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var h, m, s int
 	switch t.Period {
 	case SpecificTime:
 		h, m, s = t.Hour, t.Minute, t.Second
-	case Morning:
-		h = 9
-	case Afternoon:
-		h = 13
-	case Evening:
-		h = 17
-	case Night:
-		h = 20
+	default:
+		h = periodHour[t.Period]
+	}
+	return time.Date(t.Year, time.Month(t.Month), t.Day, h, m, s, 0, o.loc)
+}
+
+// NewTime builds a Time from a time.Time, in the timezone that time.Time is
+// already in. If period is SpecificTime, the Hour, Minute, and Second are
+// taken from t exactly; if period is NearestPeriod, whichever of Morning,
+// Afternoon, Evening, or Night is closest to t's hour is used instead; any
+// other Period is used as given.
+func NewTime(t time.Time, period Period) Time {
+	nt := Time{Year: t.Year(), Month: int(t.Month()), Day: t.Day()}
+
+	switch period {
+	case SpecificTime:
+		nt.Hour, nt.Minute, nt.Second = t.Hour(), t.Minute(), t.Second()
+	case NearestPeriod:
+		nt.Period = nearestPeriod(t.Hour())
+	default:
+		nt.Period = period
 	}
-	return time.Date(t.Year, time.Month(t.Month), t.Day, h, m, s, 0, time.Now().Location())
+
+	return nt
+}
+
+// nearestPeriod returns whichever of Morning, Afternoon, Evening, or Night
+// has an hour closest to hour, on a 24-hour wraparound clock.
+func nearestPeriod(hour int) Period {
+	best := Morning
+	bestDiff := 25
+	for _, p := range [...]Period{Morning, Afternoon, Evening, Night} {
+		diff := hour - periodHour[p]
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > 12 {
+			diff = 24 - diff
+		}
+		if diff < bestDiff {
+			bestDiff, best = diff, p
+		}
+	}
+	return best
 }
 
 // Dismissed is a boolean type that serializes to DISMISSED or INITIAL
@@ -201,6 +298,10 @@ func (p Period) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements json.Unmarhsaler.UnmarshalJSON.
 func (p *Period) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(jsonNull, b) {
+		*p = SpecificTime
+		return nil
+	}
 	// note, Period should always be omitempty, so we don't support
 	// deserializing the default value here.
 	switch {
@@ -246,6 +347,10 @@ func (c Color) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements json.Unmarhsaler.UnmarshalJSON.
 func (c *Color) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(jsonNull, b) {
+		*c = DefaultColor
+		return nil
+	}
 	switch {
 	case bytes.Equal(defaultVal, b):
 		*c = DefaultColor
@@ -269,30 +374,177 @@ func (c *Color) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Weekday names the day of the week a recurring Reminder fires on. The zero
+// value, NoWeekday, means the reminder doesn't recur.
+type Weekday int
+
+// These are values for the Weekday field of Time.
+const (
+	NoWeekday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+	Sunday
+)
+
+// MarshalJSON implements json.Marhsaler.MarshalJSON.
+func (w Weekday) MarshalJSON() ([]byte, error) {
+	// note, Weekday should always be omitempty, so we don't support
+	// serializing the default value here.
+	switch w {
+	case Monday:
+		return mondayVal, nil
+	case Tuesday:
+		return tuesdayVal, nil
+	case Wednesday:
+		return wednesdayVal, nil
+	case Thursday:
+		return thursdayVal, nil
+	case Friday:
+		return fridayVal, nil
+	case Saturday:
+		return saturdayVal, nil
+	case Sunday:
+		return sundayVal, nil
+	}
+	return nil, fmt.Errorf("unsupported weekday value %d", w)
+}
+
+// UnmarshalJSON implements json.Unmarhsaler.UnmarshalJSON.
+func (w *Weekday) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(jsonNull, b) {
+		*w = NoWeekday
+		return nil
+	}
+	switch {
+	case bytes.Equal(mondayVal, b):
+		*w = Monday
+	case bytes.Equal(tuesdayVal, b):
+		*w = Tuesday
+	case bytes.Equal(wednesdayVal, b):
+		*w = Wednesday
+	case bytes.Equal(thursdayVal, b):
+		*w = Thursday
+	case bytes.Equal(fridayVal, b):
+		*w = Friday
+	case bytes.Equal(saturdayVal, b):
+		*w = Saturday
+	case bytes.Equal(sundayVal, b):
+		*w = Sunday
+	default:
+		return fmt.Errorf("unexpected Weekday value %q", b)
+	}
+	return nil
+}
+
+// TimestampFormat selects the wire encoding Timestamp.MarshalJSON uses.
+// UnmarshalJSON always accepts all of them, since Google Keep itself isn't
+// consistent about which one shows up in a given response.
+type TimestampFormat int
+
+// These are values for MarshalFormat.
+const (
+	// KeepFormat is the fixed-layout string Google Keep itself sends, e.g.
+	// "2021-01-02T15:04:05.000Z". This is the default.
+	KeepFormat TimestampFormat = iota
+	// RFC3339Format is time.Time's usual JSON encoding, e.g.
+	// "2021-01-02T15:04:05.000000005-07:00".
+	RFC3339Format
+	// UnixFormat is a bare JSON number of seconds since the Unix epoch, e.g.
+	// 1609599845.
+	UnixFormat
+)
+
+// MarshalFormat controls the format Timestamp.MarshalJSON encodes with.
+// Code that only talks to Google Keep should leave this at its default,
+// KeepFormat; it exists for callers reusing these types against some other
+// backend.
+var MarshalFormat = KeepFormat
+
 // Timestamp is a time that serializes to a string where time zero = 1970.
 type Timestamp time.Time
 
-// MarshalJSON implements json.Marhsaler.MarshalJSON.
+// MarshalJSON implements json.Marhsaler.MarshalJSON. It encodes using the
+// package-level MarshalFormat. Callers that can't tolerate another part of
+// the same process changing MarshalFormat out from under them - such as a
+// client talking to a specific backend - should call Format with an
+// explicit TimestampFormat instead.
 func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return t.Format(MarshalFormat)
+}
+
+// Format encodes t in the given TimestampFormat, ignoring the package-level
+// MarshalFormat.
+func (t Timestamp) Format(format TimestampFormat) ([]byte, error) {
 	tm := time.Time(t)
-	if tm.IsZero() {
-		return tszero, nil
+	switch format {
+	case RFC3339Format:
+		return tm.MarshalJSON()
+	case UnixFormat:
+		return []byte(strconv.FormatInt(tm.Unix(), 10)), nil
+	default:
+		if tm.IsZero() {
+			return tszero, nil
+		}
+		return []byte(`"` + tm.Format(tsLayout) + `"`), nil
 	}
-	return []byte(tm.Format(tsLayout)), nil
 }
 
-// UnmarshalJSON implements json.Unmarhsaler.UnmarshalJSON.
+// UnmarshalJSON implements json.Unmarhsaler.UnmarshalJSON. It accepts
+// whichever of KeepFormat, RFC3339Format, or UnixFormat the input is
+// encoded with, trying each in turn, since Google Keep's sync responses
+// aren't consistent about which one a given field uses.
 func (t *Timestamp) UnmarshalJSON(b []byte) error {
-	if bytes.Equal(tszero, b) {
+	if bytes.Equal(tszero, b) || bytes.Equal(jsonNull, b) {
 		*t = Timestamp{}
 		return nil
 	}
-	tm, err := time.Parse(tsLayout, string(b))
+
+	s := strings.Trim(string(b), `"`)
+
+	if tm, err := time.Parse(tsLayout, s); err == nil {
+		*t = Timestamp(tm)
+		return nil
+	}
+
+	var tm time.Time
+	if err := tm.UnmarshalText([]byte(s)); err == nil {
+		*t = Timestamp(tm)
+		return nil
+	}
+
+	if tm, err := parseUnixTimestamp(s); err == nil {
+		*t = Timestamp(tm)
+		return nil
+	}
+
+	return fmt.Errorf("wire: unrecognized timestamp %q", b)
+}
+
+// parseUnixTimestamp parses s as a number of seconds since the Unix epoch,
+// with an optional fractional (nanosecond) part, e.g. "1609599845" or
+// "1609599845.5".
+func parseUnixTimestamp(s string) (time.Time, error) {
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	sec, err := strconv.ParseInt(whole, 10, 64)
 	if err != nil {
-		return err
+		return time.Time{}, fmt.Errorf("wire: parsing unix seconds: %w", err)
 	}
-	*t = Timestamp(tm)
-	return nil
+
+	var nsec int64
+	if hasFrac {
+		frac = (frac + "000000000")[:9]
+		nsec, err = strconv.ParseInt(frac, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("wire: parsing unix nanoseconds: %w", err)
+		}
+	}
+
+	return time.Unix(sec, nsec).UTC(), nil
 }
 
 // TSKind outputs the correct json for the kind field of the timestamps struct.
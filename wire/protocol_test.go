@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestPeriodMarshalEmpty(t *testing.T) {
@@ -21,3 +22,154 @@ func TestPeriodMarshalEmpty(t *testing.T) {
 		t.Fatalf("expected: %q, got: %q", expect, b)
 	}
 }
+
+func TestTimestampUnmarshalFormats(t *testing.T) {
+	want := time.Date(2021, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []string{
+		`"2021-01-02T15:04:05.000Z"`,
+		`"2021-01-02T15:04:05Z"`,
+		`"2021-01-02T16:04:05+01:00"`,
+		`1609599845`,
+	}
+
+	for _, in := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(in), &ts); err != nil {
+			t.Fatalf("unmarshaling %q: %v", in, err)
+		}
+		got := time.Time(ts)
+		if !got.Equal(want) {
+			t.Fatalf("unmarshaling %q: got %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestNewTimeRoundTripsLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	in := time.Date(2024, time.March, 15, 14, 30, 0, 0, loc)
+
+	nt := NewTime(in, SpecificTime)
+	got := nt.Time(WithLocation(loc))
+	if !got.Equal(in) {
+		t.Fatalf("got %v, want %v", got, in)
+	}
+	if got.Location().String() != loc.String() {
+		t.Fatalf("got location %v, want %v", got.Location(), loc)
+	}
+}
+
+func TestNewTimeNearestPeriod(t *testing.T) {
+	tests := []struct {
+		hour int
+		want Period
+	}{
+		{hour: 8, want: Morning},
+		{hour: 12, want: Afternoon},
+		{hour: 16, want: Evening},
+		{hour: 22, want: Night},
+		{hour: 1, want: Night},
+	}
+	for _, tc := range tests {
+		in := time.Date(2024, time.March, 15, tc.hour, 0, 0, 0, time.UTC)
+		nt := NewTime(in, NearestPeriod)
+		if nt.Period != tc.want {
+			t.Errorf("hour %d: got Period %d, want %d", tc.hour, nt.Period, tc.want)
+		}
+	}
+}
+
+func TestWeekdayRoundTrip(t *testing.T) {
+	b, err := json.Marshal(Friday)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := []byte(`"FRI"`)
+	if !bytes.Equal(expect, b) {
+		t.Fatalf("expected: %q, got: %q", expect, b)
+	}
+
+	var w Weekday
+	if err := json.Unmarshal(b, &w); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if w != Friday {
+		t.Fatalf("got %d, want %d", w, Friday)
+	}
+}
+
+// TestReminderUnmarshalPartiallyNull checks that a Reminder whose time-of-day
+// hasn't been set yet - where Google Keep sends "period": null rather than
+// omitting the field - unmarshals instead of erroring.
+func TestReminderUnmarshalPartiallyNull(t *testing.T) {
+	const payload = `{
+		"state": "INITIAL",
+		"description": "Buy milk",
+		"year": 2024,
+		"month": 3,
+		"day": 15,
+		"period": null
+	}`
+
+	var r Reminder
+	if err := json.Unmarshal([]byte(payload), &r); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Period != SpecificTime {
+		t.Fatalf("got Period %d, want %d", r.Period, SpecificTime)
+	}
+	if r.Description != "Buy milk" {
+		t.Fatalf("got Description %q, want %q", r.Description, "Buy milk")
+	}
+}
+
+// TestReminderUnmarshalWeekdayNull checks that a Reminder whose weekday
+// hasn't been set yet - where Google Keep sends "weekday": null rather than
+// omitting the field - unmarshals instead of erroring, the same way
+// TestReminderUnmarshalPartiallyNull checks for period.
+func TestReminderUnmarshalWeekdayNull(t *testing.T) {
+	const payload = `{
+		"state": "INITIAL",
+		"description": "Buy milk",
+		"year": 2024,
+		"month": 3,
+		"day": 15,
+		"weekday": null
+	}`
+
+	var r Reminder
+	if err := json.Unmarshal([]byte(payload), &r); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Weekday != NoWeekday {
+		t.Fatalf("got Weekday %d, want %d", r.Weekday, NoWeekday)
+	}
+}
+
+// TestTimestampsUnmarshalPartiallyNull checks that a Timestamps struct whose
+// deleted/trashed fields are still null, rather than the zero-time sentinel,
+// unmarshals instead of erroring.
+func TestTimestampsUnmarshalPartiallyNull(t *testing.T) {
+	const payload = `{
+		"kind": "notes#timestamps",
+		"created": "2021-03-04T18:22:01.000Z",
+		"deleted": null,
+		"trashed": null,
+		"updated": "2021-03-04T18:22:01.000Z",
+		"userEdited": "2021-03-04T18:22:01.000Z"
+	}`
+
+	var ts Timestamps
+	if err := json.Unmarshal([]byte(payload), &ts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !time.Time(ts.Deleted).IsZero() {
+		t.Fatalf("got Deleted %v, want zero", time.Time(ts.Deleted))
+	}
+	if !time.Time(ts.Trashed).IsZero() {
+		t.Fatalf("got Trashed %v, want zero", time.Time(ts.Trashed))
+	}
+}